@@ -0,0 +1,21 @@
+// Command lambda is the AWS Lambda entrypoint. It is a thin adapter over
+// the pipeline package; see cmd/sync and cmd/server for the other ways to
+// run the same logic.
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/tommy-bradbury/openai-upload-product-data/src/pipeline"
+	_ "github.com/tommy-bradbury/openai-upload-product-data/src/productsource/all"
+)
+
+func main() {
+	lambda.Start(handleRequest)
+}
+
+func handleRequest(ctx context.Context) error {
+	return pipeline.Run(ctx, pipeline.ConfigFromEnv())
+}
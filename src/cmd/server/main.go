@@ -0,0 +1,47 @@
+// Command server exposes the pipeline over HTTP, so it can run on
+// ECS/Fargate or be triggered by an external cron instead of Lambda.
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/tommy-bradbury/openai-upload-product-data/src/pipeline"
+	_ "github.com/tommy-bradbury/openai-upload-product-data/src/productsource/all"
+)
+
+func main() {
+	addr := ":" + envOrDefault("PORT", "8080")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", handleHealthz)
+	mux.HandleFunc("POST /sync", handleSync)
+
+	log.Printf("listening on %s", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Fatalf("server failed: %v", err)
+	}
+}
+
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
+
+func handleSync(w http.ResponseWriter, r *http.Request) {
+	if err := pipeline.Run(r.Context(), pipeline.ConfigFromEnv()); err != nil {
+		log.Printf("sync failed: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("synced"))
+}
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
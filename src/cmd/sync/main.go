@@ -0,0 +1,76 @@
+// Command sync runs the pipeline once from the command line, against
+// either a local JSON file or a DynamoDB table, without needing a Lambda
+// invocation to test changes.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/tommy-bradbury/openai-upload-product-data/src/pipeline"
+	"github.com/tommy-bradbury/openai-upload-product-data/src/productsource"
+	_ "github.com/tommy-bradbury/openai-upload-product-data/src/productsource/all"
+)
+
+func init() {
+	// "file" is a dev-only driver: read products straight out of a local
+	// JSON file instead of DynamoDB/S3/HTTP.
+	productsource.RegisterDriver("file", newLocalFileSource)
+}
+
+func main() {
+	source := flag.String("source", "", "product source driver: dynamodb, s3, http, or file (default: $PRODUCT_SOURCE_DRIVER or dynamodb)")
+	file := flag.String("file", "", "path to a local products JSON file (implies -source=file)")
+	table := flag.String("table", "", "DynamoDB table name (overrides $DYNAMODB_PRODUCTS_TABLE)")
+	force := flag.Bool("force", false, "re-upload every category regardless of digest match")
+	flag.Parse()
+
+	cfg := pipeline.ConfigFromEnv()
+
+	switch {
+	case *file != "":
+		cfg.ProductSourceDriver = "file"
+		cfg.ProductSourceAdditional["path"] = *file
+	case *source != "":
+		cfg.ProductSourceDriver = *source
+	}
+	if *table != "" {
+		cfg.ProductSourceAdditional["table"] = *table
+	}
+	if *force {
+		cfg.ForceReupload = true
+	}
+
+	if err := pipeline.Run(context.Background(), cfg); err != nil {
+		log.Fatalf("sync failed: %v", err)
+	}
+}
+
+type localFileSource struct {
+	path string
+}
+
+func newLocalFileSource(cfg productsource.Config) (productsource.ProductSource, error) {
+	path, ok := cfg.Get("path")
+	if !ok || path == "" {
+		return nil, fmt.Errorf("file driver: -file not set")
+	}
+	return localFileSource{path: path}, nil
+}
+
+func (s localFileSource) Fetch(ctx context.Context) ([]productsource.Product, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.path, err)
+	}
+
+	var products []productsource.Product
+	if err := json.Unmarshal(data, &products); err != nil {
+		return nil, fmt.Errorf("failed to decode products JSON from %s: %w", s.path, err)
+	}
+	return products, nil
+}
@@ -0,0 +1,58 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/tommy-bradbury/openai-upload-product-data/src/productsource"
+)
+
+// Product is an alias of the shared productsource record so the rest of
+// this package doesn't need to change shape.
+type Product = productsource.Product
+
+// each individual product_id:attribute mapping inside each attribute in attributes_list
+type AttributeValues map[string]string
+
+// each attribute section inside attributes_list
+type AttributesList map[string]AttributeValues
+
+// each category with its products listed within the attributes
+type OutputCategory struct {
+	Name           string         `json:"name"`
+	AttributesList AttributesList `json:"attributes_list"`
+}
+
+func convertProductFormat(products []Product) []OutputCategory {
+
+	// split the products out into the categories
+	categorisedProducts := make(map[string][]Product)
+	for _, product := range products {
+		categorisedProducts[product.Category] = append(categorisedProducts[product.Category], product)
+	}
+
+	var reJigged []OutputCategory
+	for categoryName, products := range categorisedProducts {
+		categoryOutput := OutputCategory{
+			Name:           categoryName,
+			AttributesList: make(AttributesList),
+		}
+
+		// All attributes are strictly consistent at an earlier point,
+		// so assume the first element is representative of a consistent
+		// structure
+		if len(products) > 0 && products[0].Attributes != nil {
+			for attrName := range products[0].Attributes {
+				categoryOutput.AttributesList[attrName] = make(AttributeValues)
+			}
+		}
+
+		for _, product := range products {
+			for attrName, attrValue := range product.Attributes {
+				categoryOutput.AttributesList[attrName][product.ProductID] = fmt.Sprintf("%v", attrValue)
+			}
+		}
+		reJigged = append(reJigged, categoryOutput)
+	}
+
+	return reJigged
+}
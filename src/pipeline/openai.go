@@ -0,0 +1,304 @@
+package pipeline
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+const (
+	purpose = openai.PurposeAssistants
+
+	// digestSeparator joins the per-category file name to the content
+	// digest in the name we actually upload to OpenAI, e.g.
+	// "products-running-shoes-cdaf51ea49.json@3a7c...". This is how we track what's currently
+	// attached without needing a separate metadata store.
+	digestSeparator = "@"
+)
+
+type OpenAIClient struct {
+	client               *openai.Client
+	assistantID          string
+	productsJSONFileName string
+}
+
+// NewOpenAIClient initialises and returns a new OpenAIClient.
+func NewOpenAIClient(assistantID, openAICredential, productsJSONFileName string) (*OpenAIClient, error) {
+	if assistantID == "" {
+		return nil, fmt.Errorf("ASSISTANT_PRODUCT_PICKER environment variable not set")
+	}
+	if openAICredential == "" {
+		return nil, fmt.Errorf("OPEN_AI_CREDENTIAL environment variable not set")
+	}
+	if productsJSONFileName == "" {
+		return nil, fmt.Errorf("PRODUCTS_FILE_NAME environment variable not set")
+	}
+
+	// Configure the client to use Assistants API v2
+	config := openai.DefaultConfig(openAICredential)
+	config.AssistantVersion = "v2"
+
+	client := openai.NewClientWithConfig(config)
+
+	return &OpenAIClient{
+		client:               client,
+		assistantID:          assistantID,
+		productsJSONFileName: productsJSONFileName,
+	}, nil
+}
+
+// digestOf returns a hex SHA-256 digest of the marshaled products JSON, used
+// to detect whether the currently-attached file is already up to date.
+func digestOf(JSONBytes []byte) string {
+	sum := sha256.Sum256(JSONBytes)
+	return hex.EncodeToString(sum[:])
+}
+
+// splitDigestSuffix splits an uploaded file name of the form
+// "<name><digestSeparator><digest>" back into its parts. ok is false if the
+// name doesn't carry a digest suffix (e.g. a file from before this change).
+func splitDigestSuffix(fileName string) (name, digest string, ok bool) {
+	idx := strings.LastIndex(fileName, digestSeparator)
+	if idx < 0 {
+		return fileName, "", false
+	}
+	return fileName[:idx], fileName[idx+len(digestSeparator):], true
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// categoryFileName derives the per-category file name uploaded to OpenAI
+// from the configured base products file name, e.g. "products.json" +
+// category "Running Shoes" -> "products-running-shoes-cdaf51ea49.json".
+//
+// The human-readable slug alone is NOT unique (e.g. "Men's Shoes" and
+// "Mens Shoes", or categories differing only by case, collapse to the same
+// slug), so a short hash of the untransformed category name is appended to
+// keep this injective; the diff in replaceProductsJSONFileInOpenAI relies
+// on that to avoid silently clobbering one category's file with another's.
+func (oc *OpenAIClient) categoryFileName(category string) string {
+	base := strings.TrimSuffix(oc.productsJSONFileName, ".json")
+	slug := strings.Trim(unsafeFileNameChars.ReplaceAllString(strings.ToLower(category), "-"), "-")
+	nameDigest := sha256.Sum256([]byte(category))
+	return fmt.Sprintf("%s-%s-%s.json", base, slug, hex.EncodeToString(nameDigest[:])[:10])
+}
+
+// categoryFile is what we know about one category's currently-attached
+// vector store file.
+type categoryFile struct {
+	fileID string
+	digest string
+}
+
+// checkForFileNameCollisions fails loudly if two categories would map to
+// the same uploaded file name, rather than letting the diff below silently
+// clobber one category's existing/new file with another's.
+func checkForFileNameCollisions(categories []OutputCategory, fileName func(string) string) error {
+	seen := make(map[string]string, len(categories))
+	for _, category := range categories {
+		name := fileName(category.Name)
+		if other, dup := seen[name]; dup {
+			return fmt.Errorf("category file name collision: %q and %q both map to %q", other, category.Name, name)
+		}
+		seen[name] = category.Name
+	}
+	return nil
+}
+
+// replaceProductsJSONFileInOpenAI diffs categories against the set of
+// per-category files already attached to the vector store: unchanged
+// categories are left alone, new/changed ones are uploaded and attached in
+// one batch, and categories no longer present are detached and deleted.
+// forceReupload re-uploads every category regardless of digest match.
+func (oc *OpenAIClient) replaceProductsJSONFileInOpenAI(ctx context.Context, categories []OutputCategory, forceReupload bool) error {
+	if len(categories) == 0 {
+		return fmt.Errorf("refusing to replace vector store files: fetched zero categories (treating this as a failed/incomplete fetch rather than deleting everything already attached)")
+	}
+
+	vectorStoreID, err := oc.getAssistantVectorStoreID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get or create assistant's vector store: %w", err)
+	}
+
+	existingFiles, err := oc.listFilesInVectorStore(ctx, vectorStoreID)
+	if err != nil {
+		return fmt.Errorf("failed to list files in vector store: %w", err)
+	}
+
+	existingByName := make(map[string]categoryFile, len(existingFiles))
+	for _, file := range existingFiles {
+		fileDetails, fileErr := oc.client.GetFile(ctx, file.ID)
+		if fileErr != nil {
+			log.Printf("Warning: Could not retrieve details for file %s in vector store: %v", file.ID, fileErr)
+			continue // Skip this file if details can't be retrieved
+		}
+		if name, digest, ok := splitDigestSuffix(fileDetails.FileName); ok {
+			existingByName[name] = categoryFile{fileID: file.ID, digest: digest}
+		}
+	}
+
+	if err := checkForFileNameCollisions(categories, oc.categoryFileName); err != nil {
+		return err
+	}
+
+	var staleFileIDs []string
+	var newFileIDs []string
+
+	for _, category := range categories {
+		JSONBytes, err := json.Marshal(category)
+		if err != nil {
+			return fmt.Errorf("failed to marshal category %q: %w", category.Name, err)
+		}
+		name := oc.categoryFileName(category.Name)
+		digest := digestOf(JSONBytes)
+
+		existing, hadExisting := existingByName[name]
+		delete(existingByName, name) // whatever's left afterwards has been removed upstream
+
+		if hadExisting && existing.digest == digest && !forceReupload {
+			log.Printf("Category file '%s' already matches digest %s; skipping re-upload.\n", name, digest)
+			continue
+		}
+		if hadExisting {
+			staleFileIDs = append(staleFileIDs, existing.fileID)
+		}
+
+		uploadedFile, err := oc.client.CreateFileBytes(ctx, openai.FileBytesRequest{
+			Name:    name + digestSeparator + digest,
+			Bytes:   JSONBytes,
+			Purpose: purpose,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to upload category file %q: %w", name, err)
+		}
+		newFileIDs = append(newFileIDs, uploadedFile.ID)
+	}
+
+	// Anything left in existingByName is a category that no longer exists.
+	for name, file := range existingByName {
+		log.Printf("Category file '%s' no longer present upstream; detaching and deleting.\n", name)
+		staleFileIDs = append(staleFileIDs, file.fileID)
+	}
+
+	for _, fileID := range staleFileIDs {
+		if err := oc.deleteFileFromOpenAIAndVectorStore(ctx, vectorStoreID, fileID); err != nil {
+			return fmt.Errorf("failed to delete stale category file %s: %w", fileID, err)
+		}
+	}
+
+	if len(newFileIDs) == 0 {
+		log.Println("No category files changed; nothing to attach.")
+		return nil
+	}
+
+	if _, err := oc.client.CreateVectorStoreFileBatch(ctx, vectorStoreID, openai.VectorStoreFileBatchRequest{
+		FileIDs: newFileIDs,
+	}); err != nil {
+		return fmt.Errorf("failed to batch-attach %d category files to vector store %s: %w", len(newFileIDs), vectorStoreID, err)
+	}
+
+	log.Printf("Successfully replaced %d category file(s) in OpenAI via Vector Store.\n", len(newFileIDs))
+	return nil
+}
+
+// get id of Vector Store for assistant, provisioning one if the assistant
+// doesn't have one attached yet.
+func (oc *OpenAIClient) getAssistantVectorStoreID(ctx context.Context) (string, error) {
+	assistant, err := oc.client.RetrieveAssistant(ctx, oc.assistantID)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve assistant %s: %w", oc.assistantID, err)
+	}
+
+	// vector storage stuff is found within the nested ToolResources field of the Assistant struct.
+	// These are accessed directly as fields of the `assistant` object.
+	if assistant.ToolResources != nil &&
+		assistant.ToolResources.FileSearch != nil &&
+		len(assistant.ToolResources.FileSearch.VectorStoreIDs) > 0 {
+		vectorStoreID := assistant.ToolResources.FileSearch.VectorStoreIDs[0]
+		log.Printf("Found existing Vector Store ID: %s associated with assistant.", vectorStoreID)
+		return vectorStoreID, nil
+	}
+
+	log.Printf("Assistant %s has no Vector Store attached; provisioning one.", oc.assistantID)
+	return oc.createAndAttachVectorStore(ctx, assistant.ToolResources)
+}
+
+// createAndAttachVectorStore creates a new Vector Store for this assistant
+// and attaches it via ModifyAssistant, so first-run deployment works
+// without any manual console setup. existingToolResources is whatever the
+// assistant already had (e.g. a CodeInterpreter association) and is carried
+// over unchanged, since ModifyAssistant replaces tool_resources wholesale
+// rather than merging it per-key.
+func (oc *OpenAIClient) createAndAttachVectorStore(ctx context.Context, existingToolResources *openai.AssistantToolResource) (string, error) {
+	vectorStore, err := oc.client.CreateVectorStore(ctx, openai.VectorStoreRequest{
+		Name: fmt.Sprintf("products-%s", oc.assistantID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create vector store for assistant %s: %w", oc.assistantID, err)
+	}
+
+	toolResources := &openai.AssistantToolResource{}
+	if existingToolResources != nil {
+		*toolResources = *existingToolResources
+	}
+	toolResources.FileSearch = &openai.AssistantToolFileSearch{
+		VectorStoreIDs: []string{vectorStore.ID},
+	}
+
+	_, err = oc.client.ModifyAssistant(ctx, oc.assistantID, openai.AssistantRequest{
+		ToolResources: toolResources,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to attach vector store %s to assistant %s: %w", vectorStore.ID, oc.assistantID, err)
+	}
+
+	log.Printf("Created and attached new Vector Store ID: %s to assistant %s.", vectorStore.ID, oc.assistantID)
+	return vectorStore.ID, nil
+}
+
+// listFilesInVectorStore returns every file currently attached to the
+// vector store, paging through the listing since there's one file per
+// category rather than a single monolithic upload.
+func (oc *OpenAIClient) listFilesInVectorStore(ctx context.Context, vectorStoreID string) ([]openai.VectorStoreFile, error) {
+	limit := 100
+	orderBy := "desc"
+	var after *string
+	var all []openai.VectorStoreFile
+
+	for {
+		page, err := oc.client.ListVectorStoreFiles(ctx, vectorStoreID, openai.Pagination{
+			Limit: &limit,
+			After: after,
+			Order: &orderBy,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error listing files in Vector Store %s: %w", vectorStoreID, err)
+		}
+		all = append(all, page.VectorStoreFiles...)
+		if !page.HasMore || page.LastID == "" {
+			break
+		}
+		after = &page.LastID
+	}
+	return all, nil
+}
+
+func (oc *OpenAIClient) deleteFileFromOpenAIAndVectorStore(ctx context.Context, vectorStoreID, fileID string) error {
+	err := oc.client.DeleteVectorStoreFile(ctx, vectorStoreID, fileID)
+	if err != nil {
+		return fmt.Errorf("error deleting file %s from Vector Store %s: %w", fileID, vectorStoreID, err)
+	}
+
+	err = oc.client.DeleteFile(ctx, fileID)
+	if err != nil {
+		return fmt.Errorf("error deleting file %s from OpenAI storage: %w", fileID, err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCategoryFileNameIsInjective(t *testing.T) {
+	oc := &OpenAIClient{productsJSONFileName: "products.json"}
+
+	cases := []string{
+		"Men's Shoes",
+		"Mens Shoes",
+		"Home & Garden",
+		"Home And Garden",
+		"shoes",
+		"Shoes",
+		"SHOES",
+	}
+
+	seen := make(map[string]string, len(cases))
+	for _, category := range cases {
+		name := oc.categoryFileName(category)
+		if other, dup := seen[name]; dup {
+			t.Fatalf("categoryFileName(%q) == categoryFileName(%q) == %q, want distinct names", category, other, name)
+		}
+		seen[name] = category
+	}
+}
+
+func TestCheckForFileNameCollisions(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []OutputCategory
+		fileName   func(string) string
+		wantErr    bool
+	}{
+		{
+			name: "no collision",
+			categories: []OutputCategory{
+				{Name: "Shoes"},
+				{Name: "Hats"},
+			},
+			fileName: func(s string) string { return s },
+			wantErr:  false,
+		},
+		{
+			name: "collision",
+			categories: []OutputCategory{
+				{Name: "Shoes"},
+				{Name: "SHOES"},
+			},
+			fileName: func(s string) string { return "shoes.json" },
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkForFileNameCollisions(tt.categories, tt.fileName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkForFileNameCollisions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReplaceProductsJSONFileInOpenAIRefusesEmptyCategories(t *testing.T) {
+	oc := &OpenAIClient{productsJSONFileName: "products.json"}
+
+	err := oc.replaceProductsJSONFileInOpenAI(context.Background(), nil, false)
+	if err == nil {
+		t.Fatal("replaceProductsJSONFileInOpenAI(nil categories) = nil error, want an error rather than deleting every attached file")
+	}
+}
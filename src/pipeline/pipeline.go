@@ -0,0 +1,99 @@
+// Package pipeline holds the product ingestion and OpenAI upload logic,
+// independent of whatever triggers it (Lambda, CLI, HTTP). See cmd/lambda,
+// cmd/sync and cmd/server for the entrypoints that call Run.
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/tommy-bradbury/openai-upload-product-data/src/productsource"
+)
+
+// defaultProductSourceDriver is used when PRODUCT_SOURCE_DRIVER is unset,
+// preserving the original DynamoDB-only behavior.
+const defaultProductSourceDriver = "dynamodb"
+
+// Config is everything Run needs, gathered up front so the pipeline itself
+// never reaches into the environment.
+type Config struct {
+	ProductSourceDriver     string
+	ProductSourceAdditional map[string]string
+
+	AssistantID          string
+	OpenAICredential     string
+	ProductsJSONFileName string
+	ForceReupload        bool
+}
+
+// ConfigFromEnv builds a Config from the env vars the Lambda has always
+// used, plus the newer driver/digest-related ones.
+func ConfigFromEnv() Config {
+	return Config{
+		ProductSourceDriver: envOrDefault("PRODUCT_SOURCE_DRIVER", defaultProductSourceDriver),
+		ProductSourceAdditional: map[string]string{
+			"table":       os.Getenv("DYNAMODB_PRODUCTS_TABLE"),
+			"region":      os.Getenv("PRODUCT_SOURCE_AWS_REGION"),
+			"bucket":      os.Getenv("PRODUCT_SOURCE_S3_BUCKET"),
+			"key":         os.Getenv("PRODUCT_SOURCE_S3_KEY"),
+			"format":      os.Getenv("PRODUCT_SOURCE_S3_FORMAT"),
+			"url":         os.Getenv("PRODUCT_SOURCE_HTTP_URL"),
+			"auth_header": os.Getenv("PRODUCT_SOURCE_HTTP_AUTH_HEADER"),
+		},
+		AssistantID:          os.Getenv("ASSISTANT_PRODUCT_PICKER"),
+		OpenAICredential:     os.Getenv("OPEN_AI_CREDENTIAL"),
+		ProductsJSONFileName: os.Getenv("PRODUCTS_FILE_NAME"),
+		ForceReupload:        os.Getenv("FORCE_REUPLOAD") == "true",
+	}
+}
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// Run fetches products via the configured ProductSource, reshapes them into
+// per-category output, and replaces the corresponding files in the
+// assistant's vector store. This is the single entrypoint every binary
+// (Lambda, CLI, HTTP server) calls into.
+func Run(ctx context.Context, cfg Config) error {
+	products, err := getProducts(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to get products: %w", err)
+	}
+
+	categories := convertProductFormat(products)
+
+	oc, err := NewOpenAIClient(cfg.AssistantID, cfg.OpenAICredential, cfg.ProductsJSONFileName)
+	if err != nil {
+		return fmt.Errorf("failed to initialize OpenAI client: %w", err)
+	}
+
+	if err := oc.replaceProductsJSONFileInOpenAI(ctx, categories, cfg.ForceReupload); err != nil {
+		return fmt.Errorf("failed to replace products json file on open ai: %w", err)
+	}
+
+	return nil
+}
+
+// getProducts fetches every product via the driver selected by
+// cfg.ProductSourceDriver (defaulting to the original DynamoDB scan), with
+// driver-specific settings passed through cfg.ProductSourceAdditional.
+func getProducts(ctx context.Context, cfg Config) ([]Product, error) {
+	source, err := productsource.New(productsource.Config{
+		Driver:     cfg.ProductSourceDriver,
+		Additional: cfg.ProductSourceAdditional,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build product source %q: %w", cfg.ProductSourceDriver, err)
+	}
+
+	products, err := source.Fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch products via %q driver: %w", cfg.ProductSourceDriver, err)
+	}
+	return products, nil
+}
@@ -0,0 +1,10 @@
+// Package all blank-imports every productsource driver so it registers
+// itself. Importing this package (rather than individual drivers) is the
+// normal way to wire up the full set.
+package all
+
+import (
+	_ "github.com/tommy-bradbury/openai-upload-product-data/src/productsource/dynamodb"
+	_ "github.com/tommy-bradbury/openai-upload-product-data/src/productsource/httpsource"
+	_ "github.com/tommy-bradbury/openai-upload-product-data/src/productsource/s3"
+)
@@ -0,0 +1,63 @@
+// Package productsource defines the pluggable interface the Lambda uses to
+// ingest product records, plus a registry that concrete backends (dynamodb,
+// s3, httpsource, ...) register themselves against from their own init().
+package productsource
+
+import (
+	"context"
+	"fmt"
+)
+
+// Product is the common record shape every driver must produce, regardless
+// of where it originally came from.
+type Product struct {
+	ProductID  string                 `json:"product_id" dynamodbav:"product_id"`
+	Category   string                 `json:"category" dynamodbav:"category"`
+	Attributes map[string]interface{} `json:"attributes" dynamodbav:"attributes"` // interface{} = any type
+}
+
+// ProductSource is implemented by each ingestion backend. Fetch should
+// return every product available from the backend in one call; drivers that
+// need to page internally (e.g. a DynamoDB scan) do so behind this call.
+type ProductSource interface {
+	Fetch(ctx context.Context) ([]Product, error)
+}
+
+// Config is handed to a driver factory at construction time. Additional
+// carries driver-specific settings (bucket/key, URL, table name, ...) so new
+// backends don't need their own top-level env var namespace.
+type Config struct {
+	Driver     string
+	Additional map[string]string
+}
+
+// Get reads a key out of Additional, returning ok=false if it is unset.
+func (c Config) Get(key string) (string, bool) {
+	v, ok := c.Additional[key]
+	return v, ok
+}
+
+type factory func(Config) (ProductSource, error)
+
+var drivers = make(map[string]factory)
+
+// RegisterDriver makes a driver available under name. It is meant to be
+// called from a driver package's init(), mirroring how storage backends
+// register themselves with a central registry.
+func RegisterDriver(name string, f factory) {
+	if _, exists := drivers[name]; exists {
+		panic(fmt.Sprintf("productsource: driver %q already registered", name))
+	}
+	drivers[name] = f
+}
+
+// New builds the ProductSource registered under cfg.Driver. Callers must
+// blank-import productsource/all (or the specific driver package) first so
+// that its init() has run.
+func New(cfg Config) (ProductSource, error) {
+	f, ok := drivers[cfg.Driver]
+	if !ok {
+		return nil, fmt.Errorf("productsource: unknown driver %q (forgot to import it?)", cfg.Driver)
+	}
+	return f(cfg)
+}
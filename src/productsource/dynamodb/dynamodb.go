@@ -0,0 +1,170 @@
+// Package dynamodb is the productsource driver that scans the existing
+// DynamoDB products table. This is the original (and default) ingestion
+// path.
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"github.com/tommy-bradbury/openai-upload-product-data/src/productsource"
+)
+
+const driverName = "dynamodb"
+
+// progressLogEvery controls how often Fetch logs a running item count, so
+// CloudWatch shows throughput during long scans instead of going quiet
+// until the very end.
+const progressLogEvery = 1000
+
+func init() {
+	productsource.RegisterDriver(driverName, New)
+}
+
+// Source scans a DynamoDB table and decodes every item into a Product,
+// using a paginated (optionally parallel) scan so tables bigger than the
+// 1MB single-page limit don't OOM or time out the Lambda.
+type Source struct {
+	tableName     string
+	awsRegion     string
+	pageSize      int32
+	totalSegments int32
+}
+
+// New builds a dynamodb Source from the driver config. It reads
+// DYNAMODB_PRODUCTS_TABLE (via Additional["table"]) and an optional
+// Additional["region"], defaulting to eu-west-1. Scan tuning comes from the
+// SCAN_PAGE_SIZE and SCAN_PARALLEL_SEGMENTS env vars (both optional).
+func New(cfg productsource.Config) (productsource.ProductSource, error) {
+	table, ok := cfg.Get("table")
+	if !ok || table == "" {
+		return nil, fmt.Errorf("dynamodb driver: table name not set")
+	}
+	region, ok := cfg.Get("region")
+	if !ok || region == "" {
+		region = "eu-west-1"
+	}
+
+	pageSize := int32(0) // 0 means let the SDK pick its own default
+	if raw := os.Getenv("SCAN_PAGE_SIZE"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("dynamodb driver: invalid SCAN_PAGE_SIZE %q", raw)
+		}
+		pageSize = int32(n)
+	}
+
+	totalSegments := int32(1)
+	if raw := os.Getenv("SCAN_PARALLEL_SEGMENTS"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("dynamodb driver: invalid SCAN_PARALLEL_SEGMENTS %q", raw)
+		}
+		totalSegments = int32(n)
+	}
+
+	return &Source{
+		tableName:     table,
+		awsRegion:     region,
+		pageSize:      pageSize,
+		totalSegments: totalSegments,
+	}, nil
+}
+
+// Fetch scans the table across totalSegments workers in parallel (1 by
+// default, i.e. a plain paginated scan), streaming decoded items back
+// through a channel. Each segment holds one raw scan page at a time; the
+// decoded products are all collected into the returned slice. A single
+// malformed item is logged and skipped, but a failed scan
+// page aborts its segment and Fetch returns an error rather than silently
+// reporting a partial (or empty) result as a complete catalog.
+func (s *Source) Fetch(ctx context.Context) ([]productsource.Product, error) {
+	awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(s.awsRegion))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load SDK config: %w", err)
+	}
+	client := dynamodb.NewFromConfig(awsCfg)
+
+	items := make(chan productsource.Product)
+	segmentErrs := make(chan error, s.totalSegments)
+	var wg sync.WaitGroup
+	for segment := int32(0); segment < s.totalSegments; segment++ {
+		wg.Add(1)
+		go func(segment int32) {
+			defer wg.Done()
+			if err := s.scanSegment(ctx, client, segment, items); err != nil {
+				segmentErrs <- err
+			}
+		}(segment)
+	}
+	go func() {
+		wg.Wait()
+		close(items)
+		close(segmentErrs)
+	}()
+
+	var products []productsource.Product
+	seen := 0
+	for product := range items {
+		products = append(products, product)
+		seen++
+		if seen%progressLogEvery == 0 {
+			log.Printf("dynamodb driver: scanned %d items so far", seen)
+		}
+	}
+
+	var errs []error
+	for err := range segmentErrs {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("dynamodb driver: %d of %d segment(s) failed to scan table %s: %w", len(errs), s.totalSegments, s.tableName, errors.Join(errs...))
+	}
+
+	log.Printf("dynamodb driver: finished scan, %d items total", seen)
+	return products, nil
+}
+
+// scanSegment pages through a single scan segment, decoding each item onto
+// items. A malformed item is logged and skipped since it doesn't affect the
+// completeness of the rest of the scan, but a failed page returns an error
+// so the caller knows this segment's result is incomplete.
+func (s *Source) scanSegment(ctx context.Context, client *dynamodb.Client, segment int32, items chan<- productsource.Product) error {
+	input := &dynamodb.ScanInput{
+		TableName: aws.String(s.tableName),
+	}
+	if s.pageSize > 0 {
+		input.Limit = aws.Int32(s.pageSize)
+	}
+	if s.totalSegments > 1 {
+		input.Segment = aws.Int32(segment)
+		input.TotalSegments = aws.Int32(s.totalSegments)
+	}
+
+	paginator := dynamodb.NewScanPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("segment %d: failed to scan page of table %s: %w", segment, s.tableName, err)
+		}
+		for _, rawItem := range page.Items {
+			var product productsource.Product
+			if err := attributevalue.UnmarshalMap(rawItem, &product); err != nil {
+				log.Printf("dynamodb driver: segment %d: failed to unmarshal item: %v", segment, err)
+				continue
+			}
+			items <- product
+		}
+	}
+	return nil
+}
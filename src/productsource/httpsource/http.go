@@ -0,0 +1,65 @@
+// Package httpsource is the productsource driver that fetches products from
+// a remote HTTP endpoint (e.g. a partner API), so the Lambda can ingest
+// without AWS credentials on the source side at all.
+package httpsource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/tommy-bradbury/openai-upload-product-data/src/productsource"
+)
+
+const driverName = "http"
+
+func init() {
+	productsource.RegisterDriver(driverName, New)
+}
+
+// Source fetches a JSON array of products from a single URL.
+type Source struct {
+	url        string
+	authHeader string
+}
+
+// New builds an http Source from the driver config. It requires
+// Additional["url"]; Additional["auth_header"] is sent verbatim as the
+// Authorization header if set (e.g. "Bearer <token>").
+func New(cfg productsource.Config) (productsource.ProductSource, error) {
+	url, ok := cfg.Get("url")
+	if !ok || url == "" {
+		return nil, fmt.Errorf("http driver: url not set")
+	}
+	authHeader, _ := cfg.Get("auth_header")
+	return &Source{url: url, authHeader: authHeader}, nil
+}
+
+// Fetch issues a GET against the configured URL and decodes the response
+// body as a JSON array of products.
+func (s *Source) Fetch(ctx context.Context) ([]productsource.Product, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for %s: %w", s.url, err)
+	}
+	if s.authHeader != "" {
+		req.Header.Set("Authorization", s.authHeader)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", s.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, s.url)
+	}
+
+	var products []productsource.Product
+	if err := json.NewDecoder(resp.Body).Decode(&products); err != nil {
+		return nil, fmt.Errorf("failed to decode products JSON from %s: %w", s.url, err)
+	}
+	return products, nil
+}
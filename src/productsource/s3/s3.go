@@ -0,0 +1,101 @@
+// Package s3 is the productsource driver that reads a products JSON or
+// NDJSON object out of an S3 bucket, so a staging file can be dropped in
+// without touching DynamoDB at all.
+package s3
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/tommy-bradbury/openai-upload-product-data/src/productsource"
+)
+
+const driverName = "s3"
+
+func init() {
+	productsource.RegisterDriver(driverName, New)
+}
+
+// Source fetches products from a single S3 object, either a JSON array or
+// newline-delimited JSON.
+type Source struct {
+	bucket string
+	key    string
+	ndjson bool
+}
+
+// New builds an s3 Source from the driver config. It requires
+// Additional["bucket"] and Additional["key"]; Additional["format"] may be
+// set to "ndjson" to switch parsing modes (default is a JSON array).
+func New(cfg productsource.Config) (productsource.ProductSource, error) {
+	bucket, ok := cfg.Get("bucket")
+	if !ok || bucket == "" {
+		return nil, fmt.Errorf("s3 driver: bucket not set")
+	}
+	key, ok := cfg.Get("key")
+	if !ok || key == "" {
+		return nil, fmt.Errorf("s3 driver: key not set")
+	}
+	format, _ := cfg.Get("format")
+	return &Source{bucket: bucket, key: key, ndjson: format == "ndjson"}, nil
+}
+
+// Fetch downloads the configured object and decodes it into products.
+func (s *Source) Fetch(ctx context.Context) ([]productsource.Product, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load SDK config: %w", err)
+	}
+
+	client := s3.NewFromConfig(cfg)
+	obj, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get s3://%s/%s: %w", s.bucket, s.key, err)
+	}
+	defer obj.Body.Close()
+
+	if s.ndjson {
+		return decodeNDJSON(obj.Body)
+	}
+	return decodeJSONArray(obj.Body)
+}
+
+func decodeJSONArray(r io.Reader) ([]productsource.Product, error) {
+	var products []productsource.Product
+	if err := json.NewDecoder(r).Decode(&products); err != nil {
+		return nil, fmt.Errorf("failed to decode products JSON: %w", err)
+	}
+	return products, nil
+}
+
+func decodeNDJSON(r io.Reader) ([]productsource.Product, error) {
+	var products []productsource.Product
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var product productsource.Product
+		if err := json.Unmarshal(line, &product); err != nil {
+			return nil, fmt.Errorf("failed to decode NDJSON line: %w", err)
+		}
+		products = append(products, product)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read NDJSON body: %w", err)
+	}
+	return products, nil
+}